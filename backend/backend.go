@@ -0,0 +1,59 @@
+// Package backend adapts the code generation pipeline in main to a
+// specific output language. Each backend ships a default type map and
+// template so "-backend name" alone is enough to get going; -template
+// overrides the template and a -types file extends or overrides the type
+// map.
+package backend
+
+import (
+	"sort"
+	"text/template"
+
+	"github.com/urso/go-ecsfieldgen/schema"
+)
+
+// Backend generates code for one target language from a *schema.Schema.
+type Backend interface {
+	// Name identifies the backend on the command line, e.g. "go".
+	Name() string
+
+	// Funcs returns the template functions available to this backend's
+	// templates.
+	Funcs() template.FuncMap
+
+	// PostProcess runs on the rendered template output, e.g. to gofmt Go
+	// source. Backends with nothing to post-process return contents
+	// unchanged.
+	PostProcess(contents []byte) ([]byte, error)
+
+	// DefaultTypeMap returns the base TypeRegistry; -config type_overrides
+	// and a -types file are layered on top of it.
+	DefaultTypeMap() schema.TypeRegistry
+
+	// DefaultTemplate returns the built-in template used when the caller
+	// hasn't supplied a -template file.
+	DefaultTemplate() string
+}
+
+var registry = map[string]Backend{}
+
+func register(b Backend) {
+	registry[b.Name()] = b
+}
+
+// Get returns the backend registered under name, or false if none matches.
+func Get(name string) (Backend, bool) {
+	b, ok := registry[name]
+	return b, ok
+}
+
+// Names returns the names of all registered backends, sorted for use in
+// usage/error messages.
+func Names() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,71 @@
+package backend
+
+import "github.com/urso/go-ecsfieldgen/schema"
+
+// twoNamespaceFixture builds a schema with two top-level namespaces that
+// each nest a child namespace sharing the same leaf name ("geo"), the ECS
+// shape that collides if a backend keys off a namespace's bare leaf name
+// instead of its full path.
+func twoNamespaceFixture() *schema.Schema {
+	s := &schema.Schema{
+		Version:    "test",
+		Base:       map[string]*schema.Value{},
+		Top:        map[string]*schema.Namespace{},
+		Namespaces: map[string]*schema.Namespace{},
+		Values:     map[string]*schema.Value{},
+	}
+
+	strType := schema.TypeInfo{Name: "string", JSONType: "string", TSType: "string"}
+
+	addParentWithGeo := func(name string) {
+		parent := &schema.Namespace{Name: name, FlatName: name, Description: name + " fields"}
+		geo := &schema.Namespace{Name: "geo", FlatName: name + ".geo", Parent: parent, Description: "Geo fields"}
+		loc := &schema.Value{Name: "name", FlatName: name + ".geo.name", Type: strType, Parent: geo, Description: "geo name"}
+
+		geo.Values = append(geo.Values, loc)
+		parent.Children = append(parent.Children, geo)
+
+		s.Top[name] = parent
+		s.Namespaces[name] = parent
+		s.Namespaces[name+".geo"] = geo
+		s.Values[name+".geo.name"] = loc
+	}
+
+	addParentWithGeo("source")
+	addParentWithGeo("destination")
+	return s
+}
+
+// nameCollisionFixture builds a schema with two top-level namespaces that
+// each nest a child namespace leaf-named "cfg", but with different fields
+// (source.cfg.alpha vs destination.cfg.beta) — a coincidental name reuse
+// rather than an actually reused shape.
+func nameCollisionFixture() *schema.Schema {
+	s := &schema.Schema{
+		Version:    "test",
+		Base:       map[string]*schema.Value{},
+		Top:        map[string]*schema.Namespace{},
+		Namespaces: map[string]*schema.Namespace{},
+		Values:     map[string]*schema.Value{},
+	}
+
+	strType := schema.TypeInfo{Name: "string", JSONType: "string", TSType: "string"}
+
+	addParentWithCfg := func(parentName, fieldName string) {
+		parent := &schema.Namespace{Name: parentName, FlatName: parentName, Description: parentName + " fields"}
+		cfg := &schema.Namespace{Name: "cfg", FlatName: parentName + ".cfg", Parent: parent, Description: "Config fields"}
+		val := &schema.Value{Name: fieldName, FlatName: parentName + ".cfg." + fieldName, Type: strType, Parent: cfg, Description: fieldName}
+
+		cfg.Values = append(cfg.Values, val)
+		parent.Children = append(parent.Children, cfg)
+
+		s.Top[parentName] = parent
+		s.Namespaces[parentName] = parent
+		s.Namespaces[parentName+".cfg"] = cfg
+		s.Values[parentName+".cfg."+fieldName] = val
+	}
+
+	addParentWithCfg("source", "alpha")
+	addParentWithCfg("destination", "beta")
+	return s
+}
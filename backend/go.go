@@ -0,0 +1,122 @@
+package backend
+
+import (
+	"go/format"
+	"strings"
+	"text/template"
+
+	wordwrap "github.com/mitchellh/go-wordwrap"
+	"github.com/urso/go-ecsfieldgen/schema"
+)
+
+func init() {
+	register(goBackend{})
+}
+
+// goBackend is the original, and default, backend: it renders Go struct
+// types and gofmts the result.
+type goBackend struct{}
+
+func (goBackend) Name() string { return "go" }
+
+func (goBackend) Funcs() template.FuncMap {
+	return template.FuncMap{
+		"goName":    goTypeName,
+		"goComment": goCommentify,
+	}
+}
+
+func (goBackend) PostProcess(contents []byte) ([]byte, error) {
+	return format.Source(contents)
+}
+
+func (goBackend) DefaultTypeMap() schema.TypeRegistry {
+	return schema.DefaultTypeRegistry()
+}
+
+func (goBackend) DefaultTemplate() string {
+	return goDefaultTemplate
+}
+
+const goDefaultTemplate = `// Code generated by go-ecsfieldgen. DO NOT EDIT.
+package {{.packageName}}
+
+{{range $pkg := .packages}}import "{{$pkg}}"
+{{end}}
+
+// Fields holds every ECS field known to schema version {{.schema.Version}}.
+type Fields struct {
+{{range $name, $val := .schema.Values}}{{goComment $val.Description}}
+	{{goName $val.FlatName}} {{$val.Type.Name}}
+{{end}}}
+`
+
+func goCommentify(s string) string {
+	s = strings.Join(strings.Split(s, "\n"), " ")
+	textLength := 75 - len(strings.Replace("", "\t", "    ", 4)+" // ")
+	lines := strings.Split(wordwrap.WrapString(s, uint(textLength)), "\n")
+
+	if len(lines) > 0 {
+		// Remove empty first line.
+		if strings.TrimSpace(lines[0]) == "" {
+			lines = lines[1:]
+		}
+	}
+	if len(lines) > 0 {
+		// Remove empty last line.
+		if strings.TrimSpace(lines[len(lines)-1]) == "" {
+			lines = lines[:len(lines)-1]
+		}
+	}
+
+	for i := range lines {
+		lines[i] = strings.TrimSpace(lines[i])
+	}
+
+	// remove empty lines
+	for i := len(lines) - 1; i >= 0; i-- {
+		if len(lines[i]) == 0 {
+			lines = lines[:i]
+		}
+		break
+	}
+
+	for i := range lines {
+		lines[i] = "// " + lines[i]
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func goTypeName(name string) string {
+	var b strings.Builder
+	for _, w := range strings.FieldsFunc(name, isSeparator) {
+		b.WriteString(strings.Title(abbreviations(w)))
+	}
+	return b.String()
+}
+
+// abbreviations capitalizes common abbreviations.
+func abbreviations(abv string) string {
+	switch strings.ToLower(abv) {
+	case "id", "ppid", "pid", "mac", "ip", "iana", "uid", "ecs", "url", "os",
+		"http", "dns", "ssl", "tls", "ttl", "uuid":
+		return strings.ToUpper(abv)
+	default:
+		return abv
+	}
+}
+
+// isSeparate returns true if the character is a field name separator. This is
+// used to detect the separators in fields like ephemeral_id or instance.name.
+func isSeparator(c rune) bool {
+	switch c {
+	case '.', '_':
+		return true
+	case '@':
+		// This effectively filters @ from field names.
+		return true
+	default:
+		return false
+	}
+}
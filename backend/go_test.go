@@ -0,0 +1,27 @@
+package backend
+
+import (
+	"bytes"
+	"testing"
+	"text/template"
+)
+
+func TestGoDefaultTemplateProducesValidGo(t *testing.T) {
+	b := goBackend{}
+	s := twoNamespaceFixture()
+
+	tmpl := template.Must(template.New("").Funcs(b.Funcs()).Parse(b.DefaultTemplate()))
+	var buf bytes.Buffer
+	data := map[string]interface{}{
+		"packageName": "ecs",
+		"packages":    map[string]string{},
+		"schema":      s,
+	}
+	if err := tmpl.Execute(&buf, data); err != nil {
+		t.Fatalf("executing the default template failed: %+v", err)
+	}
+
+	if _, err := b.PostProcess(buf.Bytes()); err != nil {
+		t.Fatalf("default template did not produce valid Go: %+v\n%s", err, buf.String())
+	}
+}
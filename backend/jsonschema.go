@@ -0,0 +1,198 @@
+package backend
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"text/template"
+
+	"github.com/urso/go-ecsfieldgen/schema"
+)
+
+func init() {
+	register(jsonSchemaBackend{})
+}
+
+// jsonSchemaBackend emits a Draft-2020-12 JSON Schema document, with one
+// $defs entry per top-level namespace.
+type jsonSchemaBackend struct{}
+
+func (jsonSchemaBackend) Name() string { return "jsonschema" }
+
+func (jsonSchemaBackend) Funcs() template.FuncMap {
+	return template.FuncMap{
+		"jsonSchemaDoc": jsonSchemaDoc,
+	}
+}
+
+func (jsonSchemaBackend) PostProcess(contents []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, contents, "", "  "); err != nil {
+		return nil, fmt.Errorf("invalid JSON Schema output: %+v", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (jsonSchemaBackend) DefaultTypeMap() schema.TypeRegistry {
+	return schema.DefaultTypeRegistry()
+}
+
+func (jsonSchemaBackend) DefaultTemplate() string {
+	return `{{jsonSchemaDoc .schema}}`
+}
+
+// jsonProperty is a single Draft-2020-12 JSON Schema property.
+type jsonProperty struct {
+	Type        string `json:"type,omitempty"`
+	Format      string `json:"format,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// jsonObject is a Draft-2020-12 JSON Schema object, used for both the
+// top-level $defs entries and their nested namespaces.
+type jsonObject struct {
+	Type        string                 `json:"type"`
+	Description string                 `json:"description,omitempty"`
+	Properties  map[string]interface{} `json:"properties,omitempty"`
+}
+
+// jsonRef is a Draft-2020-12 JSON Schema reference, used in place of a
+// jsonObject wherever a namespace shape is reused elsewhere in the document.
+type jsonRef struct {
+	Ref string `json:"$ref"`
+}
+
+type jsonSchemaDocument struct {
+	Schema     string                 `json:"$schema"`
+	Title      string                 `json:"title"`
+	Type       string                 `json:"type"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+	Defs       map[string]jsonObject  `json:"$defs"`
+}
+
+// jsonSchemaDoc renders s as a Draft-2020-12 JSON Schema document: the root
+// object's properties $ref one $defs entry per top-level namespace, so the
+// document actually constrains the ECS root shape rather than just
+// declaring unused $defs. Namespaces that reuse the same leaf name with an
+// identical shape in more than one place (e.g. "geo" under both "source"
+// and "destination") are factored into their own single $defs entry and
+// referenced via $ref everywhere else, rather than duplicated inline;
+// same-named namespaces whose shapes differ are each inlined.
+func jsonSchemaDoc(s *schema.Schema) (string, error) {
+	doc := jsonSchemaDocument{
+		Schema:     "https://json-schema.org/draft/2020-12/schema",
+		Title:      fmt.Sprintf("ECS %v", s.Version),
+		Type:       "object",
+		Properties: map[string]interface{}{},
+		Defs:       map[string]jsonObject{},
+	}
+
+	groups := map[string][]*schema.Namespace{}
+	for _, ns := range s.Top {
+		collectByName(ns, groups)
+	}
+	dedupable := dedupableNames(groups)
+
+	for name, ns := range s.Top {
+		doc.Defs[name] = namespaceToJSONObject(ns, dedupable, doc.Defs)
+		doc.Properties[name] = jsonRef{Ref: "#/$defs/" + name}
+	}
+
+	out, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JSON Schema document: %+v", err)
+	}
+	return string(out), nil
+}
+
+// collectByName indexes every non-top-level namespace under ns by its leaf
+// name, so dedupableNames can tell an actually reused shape from two
+// unrelated namespaces that merely happen to share a leaf name.
+func collectByName(ns *schema.Namespace, groups map[string][]*schema.Namespace) {
+	for _, child := range ns.Children {
+		groups[child.Name] = append(groups[child.Name], child)
+		collectByName(child, groups)
+	}
+}
+
+// dedupableNames returns the set of namespace names safe to factor into a
+// single shared $defs entry: every namespace sharing that name must have
+// an identical shape (same fields, same types, same children), not just
+// an identical leaf name. A name whose occurrences disagree is left out,
+// so namespaceToJSONObject falls back to inlining each one separately
+// instead of silently merging them into one and losing fields.
+func dedupableNames(groups map[string][]*schema.Namespace) map[string]bool {
+	dedupable := map[string]bool{}
+	for name, namespaces := range groups {
+		if len(namespaces) < 2 {
+			continue
+		}
+
+		shape := inlineShape(namespaces[0])
+		same := true
+		for _, ns := range namespaces[1:] {
+			if !reflect.DeepEqual(shape, inlineShape(ns)) {
+				same = false
+				break
+			}
+		}
+		dedupable[name] = same
+	}
+	return dedupable
+}
+
+// inlineShape converts ns to a JSON Schema object with every descendant
+// inlined, ignoring $defs/$ref factoring entirely. It exists only so
+// dedupableNames can compare two namespaces structurally.
+func inlineShape(ns *schema.Namespace) jsonObject {
+	obj := jsonObject{
+		Type:        "object",
+		Description: ns.Description,
+		Properties:  map[string]interface{}{},
+	}
+	for _, val := range ns.Values {
+		obj.Properties[val.Name] = jsonProperty{
+			Type:        val.Type.JSONType,
+			Format:      val.Type.JSONFormat,
+			Description: val.Description,
+		}
+	}
+	for _, child := range ns.Children {
+		obj.Properties[child.Name] = inlineShape(child)
+	}
+	return obj
+}
+
+// namespaceToJSONObject converts ns to a JSON Schema object. A child whose
+// name is in dedupable is factored into defs as a single shared entry
+// (built on first encounter) and referenced via $ref on every occurrence;
+// every other child is inlined normally.
+func namespaceToJSONObject(ns *schema.Namespace, dedupable map[string]bool, defs map[string]jsonObject) jsonObject {
+	obj := jsonObject{
+		Type:        "object",
+		Description: ns.Description,
+		Properties:  map[string]interface{}{},
+	}
+
+	for _, val := range ns.Values {
+		obj.Properties[val.Name] = jsonProperty{
+			Type:        val.Type.JSONType,
+			Format:      val.Type.JSONFormat,
+			Description: val.Description,
+		}
+	}
+
+	for _, child := range ns.Children {
+		if dedupable[child.Name] {
+			if _, ok := defs[child.Name]; !ok {
+				defs[child.Name] = namespaceToJSONObject(child, dedupable, defs)
+			}
+			obj.Properties[child.Name] = jsonRef{Ref: "#/$defs/" + child.Name}
+			continue
+		}
+		obj.Properties[child.Name] = namespaceToJSONObject(child, dedupable, defs)
+	}
+
+	return obj
+}
@@ -0,0 +1,86 @@
+package backend
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestJSONSchemaDocFactorsReusedNamespaceShapes(t *testing.T) {
+	out, err := jsonSchemaDoc(twoNamespaceFixture())
+	if err != nil {
+		t.Fatalf("jsonSchemaDoc failed: %+v", err)
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal([]byte(out), &doc); err != nil {
+		t.Fatalf("jsonSchemaDoc produced invalid JSON: %+v", err)
+	}
+
+	defs, _ := doc["$defs"].(map[string]interface{})
+	if _, ok := defs["geo"]; !ok {
+		t.Fatalf("expected a single shared '$defs.geo' entry, got $defs: %v", defs)
+	}
+
+	for _, parent := range []string{"source", "destination"} {
+		props, _ := defs[parent].(map[string]interface{})["properties"].(map[string]interface{})
+		ref, _ := props["geo"].(map[string]interface{})
+		if ref["$ref"] != "#/$defs/geo" {
+			t.Errorf("expected %v.geo to be a $ref to the shared def, got %v", parent, props["geo"])
+		}
+	}
+}
+
+// TestJSONSchemaDocRootReferencesTopNamespaces guards against the root
+// object validating any JSON object: its properties must $ref each
+// top-level namespace's $defs entry, not just declare $defs and leave the
+// root unconstrained.
+func TestJSONSchemaDocRootReferencesTopNamespaces(t *testing.T) {
+	out, err := jsonSchemaDoc(twoNamespaceFixture())
+	if err != nil {
+		t.Fatalf("jsonSchemaDoc failed: %+v", err)
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal([]byte(out), &doc); err != nil {
+		t.Fatalf("jsonSchemaDoc produced invalid JSON: %+v", err)
+	}
+
+	props, _ := doc["properties"].(map[string]interface{})
+	for _, name := range []string{"source", "destination"} {
+		ref, _ := props[name].(map[string]interface{})
+		if ref["$ref"] != "#/$defs/"+name {
+			t.Errorf("expected root.properties.%v to be a $ref to $defs.%v, got %v", name, name, props[name])
+		}
+	}
+}
+
+// TestJSONSchemaDocDoesNotMergeDifferentlyShapedNamespaces guards against
+// collapsing two namespaces that merely share a leaf name (but not a
+// shape) into a single $defs entry, which would silently drop whichever
+// one lost the race.
+func TestJSONSchemaDocDoesNotMergeDifferentlyShapedNamespaces(t *testing.T) {
+	out, err := jsonSchemaDoc(nameCollisionFixture())
+	if err != nil {
+		t.Fatalf("jsonSchemaDoc failed: %+v", err)
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal([]byte(out), &doc); err != nil {
+		t.Fatalf("jsonSchemaDoc produced invalid JSON: %+v", err)
+	}
+
+	defs, _ := doc["$defs"].(map[string]interface{})
+	if _, ok := defs["cfg"]; ok {
+		t.Fatalf("differently-shaped 'cfg' namespaces must not be merged into a shared $defs entry, got $defs: %v", defs)
+	}
+
+	sourceCfg, _ := defs["source"].(map[string]interface{})["properties"].(map[string]interface{})["cfg"].(map[string]interface{})
+	if _, ok := sourceCfg["properties"].(map[string]interface{})["alpha"]; !ok {
+		t.Errorf("expected source.cfg.alpha to survive inlined, got %v", sourceCfg)
+	}
+
+	destCfg, _ := defs["destination"].(map[string]interface{})["properties"].(map[string]interface{})["cfg"].(map[string]interface{})
+	if _, ok := destCfg["properties"].(map[string]interface{})["beta"]; !ok {
+		t.Errorf("expected destination.cfg.beta to survive inlined, got %v", destCfg)
+	}
+}
@@ -0,0 +1,117 @@
+package backend
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/urso/go-ecsfieldgen/schema"
+)
+
+func init() {
+	register(typeScriptBackend{})
+}
+
+// typeScriptBackend emits nested TypeScript interface declarations, one per
+// namespace.
+type typeScriptBackend struct{}
+
+func (typeScriptBackend) Name() string { return "typescript" }
+
+func (typeScriptBackend) Funcs() template.FuncMap {
+	return template.FuncMap{
+		"tsModule": tsModule,
+	}
+}
+
+func (typeScriptBackend) PostProcess(contents []byte) ([]byte, error) {
+	return contents, nil
+}
+
+func (typeScriptBackend) DefaultTypeMap() schema.TypeRegistry {
+	return schema.DefaultTypeRegistry()
+}
+
+func (typeScriptBackend) DefaultTemplate() string {
+	return `{{tsModule .schema}}`
+}
+
+// tsModule renders s as a set of nested TypeScript interface declarations,
+// one per namespace, doc-commented from the ECS field descriptions.
+func tsModule(s *schema.Schema) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "// Code generated by go-ecsfieldgen. DO NOT EDIT.\n\n")
+
+	for _, name := range sortedNamespaceNames(s.Top) {
+		writeNamespaceInterface(&b, s.Top[name], 0)
+	}
+	return b.String()
+}
+
+func writeNamespaceInterface(b *strings.Builder, ns *schema.Namespace, depth int) {
+	indent := strings.Repeat("  ", depth)
+	if ns.Description != "" {
+		fmt.Fprintf(b, "%v/** %v */\n", indent, ns.Description)
+	}
+	fmt.Fprintf(b, "%vexport interface %v {\n", indent, tsInterfaceName(ns.FlatName))
+
+	for _, val := range sortedValues(ns.Values) {
+		if val.Description != "" {
+			fmt.Fprintf(b, "%v  /** %v */\n", indent, val.Description)
+		}
+		fmt.Fprintf(b, "%v  %v: %v;\n", indent, val.Name, val.Type.TSType)
+	}
+
+	for _, child := range sortedNamespaces(ns.Children) {
+		fmt.Fprintf(b, "%v  %v: %v;\n", indent, child.Name, tsInterfaceName(child.FlatName))
+	}
+
+	fmt.Fprintf(b, "%v}\n\n", indent)
+
+	for _, child := range sortedNamespaces(ns.Children) {
+		writeNamespaceInterface(b, child, depth)
+	}
+}
+
+// tsInterfaceName derives an interface name from a namespace's full dotted
+// path (e.g. "source.geo"), PascalCasing each segment so namespaces that
+// share a leaf name under different parents (source.geo, destination.geo)
+// don't collide on a single top-level declaration.
+func tsInterfaceName(flatName string) string {
+	if flatName == "" {
+		return "Fields"
+	}
+
+	segments := strings.Split(flatName, ".")
+	var b strings.Builder
+	for _, seg := range segments {
+		if seg == "" {
+			continue
+		}
+		b.WriteString(strings.ToUpper(seg[:1]))
+		b.WriteString(seg[1:])
+	}
+	return b.String()
+}
+
+func sortedNamespaceNames(m map[string]*schema.Namespace) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sortedNamespaces(in []*schema.Namespace) []*schema.Namespace {
+	out := append([]*schema.Namespace(nil), in...)
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+func sortedValues(in []*schema.Value) []*schema.Value {
+	out := append([]*schema.Value(nil), in...)
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
@@ -0,0 +1,20 @@
+package backend
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTSModuleNamesNestedNamespacesByFullPath(t *testing.T) {
+	out := tsModule(twoNamespaceFixture())
+
+	for _, want := range []string{"export interface SourceGeo {", "export interface DestinationGeo {"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%v", want, out)
+		}
+	}
+
+	if strings.Contains(out, "export interface Geo {") {
+		t.Error("source.geo and destination.geo must not collide on a single 'Geo' interface")
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/urso/go-ecsfieldgen/schema"
+)
+
+// fileConfig is the on-disk representation of a -config file. It covers
+// everything a consumer would otherwise pass as a long, fragile
+// go:generate line, so a whole invocation can be reproduced from a single
+// committed file. CLI flags always take precedence over the values loaded
+// here.
+type fileConfig struct {
+	PackageName   string   `yaml:"package_name" json:"package_name" toml:"package_name"`
+	TemplateFile  string   `yaml:"template" json:"template" toml:"template"`
+	OutputFile    string   `yaml:"out" json:"out" toml:"out"`
+	Version       string   `yaml:"version" json:"version" toml:"version"`
+	FormatCode    bool     `yaml:"fmt" json:"fmt" toml:"fmt"`
+	Backend       string   `yaml:"backend" json:"backend" toml:"backend"`
+	ExcludeFields []string `yaml:"exclude_fields" json:"exclude_fields" toml:"exclude_fields"`
+	ExcludeGlobs  []string `yaml:"exclude_globs" json:"exclude_globs" toml:"exclude_globs"`
+
+	// Inputs lists the schema files/directories/glob patterns to load,
+	// used when no paths are given on the command line.
+	Inputs []string `yaml:"inputs" json:"inputs" toml:"inputs"`
+
+	// TypeOverrides declares or overrides field types, same as a -types
+	// file.
+	TypeOverrides map[string]schema.TypeOverride `yaml:"type_overrides" json:"type_overrides" toml:"type_overrides"`
+
+	// NamespaceRenames maps a namespace's flat path (e.g. "http.request")
+	// to the identifier it should be generated under instead.
+	NamespaceRenames map[string]string `yaml:"namespace_renames" json:"namespace_renames" toml:"namespace_renames"`
+}
+
+func loadFileConfig(path string) (fileConfig, error) {
+	var fc fileConfig
+	if err := schema.UnmarshalFile(path, &fc); err != nil {
+		return fileConfig{}, fmt.Errorf("failed to load config file '%v': %+v", path, err)
+	}
+	return fc, nil
+}
+
+// applyFileConfig merges fc into cfg, keeping whatever value the user set
+// explicitly on the command line (as reported by explicitFlags, the set of
+// flag.Flag.Name that flag.Visit saw).
+func applyFileConfig(cfg *config, fc fileConfig, explicitFlags map[string]bool) {
+	if !explicitFlags["pkg"] && fc.PackageName != "" {
+		cfg.PackageName = fc.PackageName
+	}
+	if !explicitFlags["template"] && fc.TemplateFile != "" {
+		cfg.TemplateFile = fc.TemplateFile
+	}
+	if !explicitFlags["out"] && fc.OutputFile != "" {
+		cfg.OutputFile = fc.OutputFile
+	}
+	if !explicitFlags["version"] && fc.Version != "" {
+		cfg.Version = fc.Version
+	}
+	if !explicitFlags["fmt"] {
+		cfg.FormatCode = fc.FormatCode
+	}
+	if !explicitFlags["backend"] && fc.Backend != "" {
+		cfg.Backend = fc.Backend
+	}
+	if !explicitFlags["e"] && len(fc.ExcludeFields) > 0 {
+		cfg.ExcludeFields = fc.ExcludeFields
+	}
+	if !explicitFlags["exclude-glob"] && len(fc.ExcludeGlobs) > 0 {
+		cfg.ExcludeGlobs = fc.ExcludeGlobs
+	}
+
+	cfg.TypeOverrides = fc.TypeOverrides
+	cfg.NamespaceRenames = fc.NamespaceRenames
+}
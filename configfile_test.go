@@ -0,0 +1,79 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/urso/go-ecsfieldgen/schema"
+)
+
+func TestLoadFileConfigParsesYAML(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yml")
+	contents := `
+package_name: ecs
+out: ecs.go
+inputs:
+  - fields.yml
+namespace_renames:
+  http.request: httpRequest
+`
+	if err := ioutil.WriteFile(path, []byte(contents), 0600); err != nil {
+		t.Fatalf("failed to write fixture: %+v", err)
+	}
+
+	fc, err := loadFileConfig(path)
+	if err != nil {
+		t.Fatalf("loadFileConfig failed: %+v", err)
+	}
+	if fc.PackageName != "ecs" || fc.OutputFile != "ecs.go" {
+		t.Errorf("unexpected fileConfig: %+v", fc)
+	}
+	if len(fc.Inputs) != 1 || fc.Inputs[0] != "fields.yml" {
+		t.Errorf("expected inputs to be [fields.yml], got %v", fc.Inputs)
+	}
+	if fc.NamespaceRenames["http.request"] != "httpRequest" {
+		t.Errorf("expected namespace_renames to be loaded, got %v", fc.NamespaceRenames)
+	}
+}
+
+func TestApplyFileConfigExplicitFlagWinsOverFile(t *testing.T) {
+	cfg := config{PackageName: "cli-value", OutputFile: "cli-out.go"}
+	fc := fileConfig{PackageName: "file-value", OutputFile: "file-out.go"}
+
+	applyFileConfig(&cfg, fc, map[string]bool{"pkg": true})
+
+	if cfg.PackageName != "cli-value" {
+		t.Errorf("expected explicit -pkg flag to win, got %v", cfg.PackageName)
+	}
+	if cfg.OutputFile != "file-out.go" {
+		t.Errorf("expected -out to be filled in from the config file, got %v", cfg.OutputFile)
+	}
+}
+
+func TestApplyFileConfigFillsUnsetValuesFromFile(t *testing.T) {
+	cfg := config{}
+	fc := fileConfig{
+		PackageName:      "ecs",
+		Backend:          "typescript",
+		ExcludeFields:    []string{"beta"},
+		TypeOverrides:    map[string]schema.TypeOverride{"wildcard": {GoType: "string"}},
+		NamespaceRenames: map[string]string{"http.request": "httpRequest"},
+	}
+
+	applyFileConfig(&cfg, fc, map[string]bool{})
+
+	if cfg.PackageName != "ecs" || cfg.Backend != "typescript" {
+		t.Errorf("expected package/backend to be filled from file config, got %+v", cfg)
+	}
+	if len(cfg.ExcludeFields) != 1 || cfg.ExcludeFields[0] != "beta" {
+		t.Errorf("expected exclude fields to be filled from file config, got %v", cfg.ExcludeFields)
+	}
+	if cfg.TypeOverrides["wildcard"].GoType != "string" {
+		t.Errorf("expected type overrides to be merged from file config, got %v", cfg.TypeOverrides)
+	}
+	if cfg.NamespaceRenames["http.request"] != "httpRequest" {
+		t.Errorf("expected namespace renames to be merged from file config, got %v", cfg.NamespaceRenames)
+	}
+}
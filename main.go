@@ -2,16 +2,17 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
-	"go/format"
 	"io/ioutil"
 	"log"
+	"os"
 	"strings"
 	"text/template"
 
-	wordwrap "github.com/mitchellh/go-wordwrap"
+	"github.com/urso/go-ecsfieldgen/backend"
 	"github.com/urso/go-ecsfieldgen/schema"
 )
 
@@ -22,8 +23,24 @@ type config struct {
 	Version       string
 	FormatCode    bool
 	ExcludeFields []string
+	ExcludeGlobs  []string
+	TypesFile     string
+	Backend       string
+	DiffAgainst   string
+	DiffFormat    string
+	ConfigFile    string
+
+	// TypeOverrides and NamespaceRenames are only settable via -config;
+	// there's no dedicated flag for either.
+	TypeOverrides    map[string]schema.TypeOverride
+	NamespaceRenames map[string]string
 }
 
+// errBreakingChanges is returned by runDiff when the schema diff contains
+// Removed or TypeChanged entries, so main can report a non-zero exit
+// without treating it as an unexpected failure.
+var errBreakingChanges = errors.New("breaking schema changes detected")
+
 type stringsFlag []string
 
 func (f *stringsFlag) String() string {
@@ -43,19 +60,82 @@ func main() {
 	flag.Parse()
 	files := flag.Args()
 
+	if cfg.ConfigFile != "" {
+		fc, err := loadFileConfig(cfg.ConfigFile)
+		if err != nil {
+			log.Fatalf("Failed to run script: %+v", err)
+		}
+
+		explicitFlags := map[string]bool{}
+		flag.Visit(func(f *flag.Flag) { explicitFlags[f.Name] = true })
+		applyFileConfig(&cfg, fc, explicitFlags)
+
+		if len(files) == 0 {
+			files = fc.Inputs
+		}
+	}
+
 	if len(files) == 0 {
 		log.Fatal("No schema files given")
 	}
 
 	checkFlag("version", cfg.Version)
+
+	if cfg.DiffAgainst != "" {
+		if err := runDiff(cfg, files); err != nil {
+			if errors.Is(err, errBreakingChanges) {
+				os.Exit(1)
+			}
+			log.Fatalf("Failed to run script: %+v", err)
+		}
+		return
+	}
+
 	if err := run(cfg, files); err != nil {
 		log.Fatalf("Failed to run script: %+v", err)
 	}
 }
 
-func run(cfg config, files []string) error {
-	if cfg.TemplateFile == "" {
-		return errors.New("no template file configured")
+func getBackend(name string) (backend.Backend, error) {
+	b, ok := backend.Get(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown backend '%v' (available: %v)", name, strings.Join(backend.Names(), ", "))
+	}
+	return b, nil
+}
+
+// loadTypeRegistry builds the TypeRegistry used for code generation,
+// layering cfg.TypeOverrides (from -config) and then the -types file (if
+// any) on top of the backend's defaults. -types is applied last so an
+// explicit CLI flag always wins over a -config file's type_overrides, the
+// same precedence the other flags get from applyFileConfig.
+func loadTypeRegistry(cfg config, b backend.Backend) (schema.TypeRegistry, error) {
+	types := b.DefaultTypeMap()
+
+	for typ, override := range cfg.TypeOverrides {
+		types.Register(typ, override.ToTypeInfo())
+	}
+
+	if cfg.TypesFile != "" {
+		custom, err := schema.LoadTypeRegistryFile(cfg.TypesFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load types file: %+v", err)
+		}
+		for typ, info := range custom {
+			types.Register(typ, info)
+		}
+	}
+
+	return types, nil
+}
+
+// runDiff compares the schema at cfg.DiffAgainst against the schema loaded
+// from files and prints a DiffReport, returning errBreakingChanges if it
+// contains any Removed or TypeChanged entries.
+func runDiff(cfg config, files []string) error {
+	b, err := getBackend(cfg.Backend)
+	if err != nil {
+		return err
 	}
 
 	ignoreNames := map[string]bool{}
@@ -63,25 +143,85 @@ func run(cfg config, files []string) error {
 		ignoreNames[name] = true
 	}
 
-	schema, err := schema.LoadFromFiles(cfg.Version, files, ignoreNames)
+	types, err := loadTypeRegistry(cfg, b)
+	if err != nil {
+		return err
+	}
+
+	oldSchema, err := schema.LoadFromFiles(cfg.Version, []string{cfg.DiffAgainst}, cfg.ExcludeGlobs, types, ignoreNames)
+	if err != nil {
+		return fmt.Errorf("failed to load old schema from '%v': %+v", cfg.DiffAgainst, err)
+	}
+
+	newSchema, err := schema.LoadFromFiles(cfg.Version, files, cfg.ExcludeGlobs, types, ignoreNames)
 	if err != nil {
 		return fmt.Errorf("failed to load schema: %+v", err)
 	}
 
-	codeTmpl, err := ioutil.ReadFile(cfg.TemplateFile)
+	report := schema.Diff(oldSchema, newSchema)
+
+	switch cfg.DiffFormat {
+	case "json":
+		out, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal diff report: %+v", err)
+		}
+		fmt.Printf("%s\n", out)
+	default:
+		for _, change := range report.Changes {
+			fmt.Println(change)
+		}
+	}
+
+	if report.Breaking() {
+		return errBreakingChanges
+	}
+	return nil
+}
+
+func run(cfg config, files []string) error {
+	b, err := getBackend(cfg.Backend)
 	if err != nil {
-		return fmt.Errorf("failed to read template file '%v': %+v", cfg.TemplateFile, err)
+		return err
 	}
 
-	contents, err := execTemplate(string(codeTmpl), cfg.PackageName, schema)
+	ignoreNames := map[string]bool{}
+	for _, name := range cfg.ExcludeFields {
+		ignoreNames[name] = true
+	}
+
+	types, err := loadTypeRegistry(cfg, b)
+	if err != nil {
+		return err
+	}
+
+	sch, err := schema.LoadFromFiles(cfg.Version, files, cfg.ExcludeGlobs, types, ignoreNames)
+	if err != nil {
+		return fmt.Errorf("failed to load schema: %+v", err)
+	}
+
+	if len(cfg.NamespaceRenames) > 0 {
+		schema.ApplyNamespaceRenames(sch, cfg.NamespaceRenames)
+	}
+
+	codeTmpl := b.DefaultTemplate()
+	if cfg.TemplateFile != "" {
+		contents, err := ioutil.ReadFile(cfg.TemplateFile)
+		if err != nil {
+			return fmt.Errorf("failed to read template file '%v': %+v", cfg.TemplateFile, err)
+		}
+		codeTmpl = string(contents)
+	}
+
+	contents, err := execTemplate(codeTmpl, cfg.PackageName, sch, b.Funcs())
 	if err != nil {
 		return fmt.Errorf("failed to apply the code template: %+v", err)
 	}
 
 	if cfg.FormatCode {
-		contents, err = format.Source(contents)
+		contents, err = b.PostProcess(contents)
 		if err != nil {
-			return fmt.Errorf("failed to format code: %v", err)
+			return fmt.Errorf("failed to post-process output: %v", err)
 		}
 	}
 
@@ -101,20 +241,21 @@ func (c *config) registerFlags(fs *flag.FlagSet) {
 		fs = flag.CommandLine
 	}
 
-	flag.StringVar(&c.TemplateFile, "template", "", "Template file used to generate the code")
+	flag.StringVar(&c.TemplateFile, "template", "", "Template file used to generate the code (defaults to the backend's built-in template)")
 	flag.StringVar(&c.PackageName, "pkg", "ecs", "Target package name")
 	flag.StringVar(&c.OutputFile, "out", "", "Output directory (required)")
 	flag.StringVar(&c.Version, "version", "", "ECS version (required)")
 	flag.BoolVar(&c.FormatCode, "fmt", false, "Format output")
 	flag.Var((*stringsFlag)(&c.ExcludeFields), "e", "exclude fields")
+	flag.Var((*stringsFlag)(&c.ExcludeGlobs), "exclude-glob", "exclude schema files matching this doublestar glob pattern (repeatable)")
+	flag.StringVar(&c.TypesFile, "types", "", "YAML/JSON/TOML file declaring additional or overridden field types")
+	flag.StringVar(&c.Backend, "backend", "go", "code generation backend: go, jsonschema, or typescript")
+	flag.StringVar(&c.DiffAgainst, "diff", "", "compare the schema at this path against the schema files given as arguments instead of generating code")
+	flag.StringVar(&c.DiffFormat, "diff-format", "text", "output format for -diff: 'text' or 'json'")
+	flag.StringVar(&c.ConfigFile, "config", "", "YAML/JSON/TOML file providing defaults for the other flags, so an invocation doesn't need to be repeated on every go:generate line")
 }
 
-func execTemplate(tmpl, pkgName string, schema *schema.Schema) ([]byte, error) {
-	funcs := template.FuncMap{
-		"goName":    goTypeName,
-		"goComment": goCommentify,
-	}
-
+func execTemplate(tmpl, pkgName string, schema *schema.Schema, funcs template.FuncMap) ([]byte, error) {
 	// collect packages to be imported
 	packages := map[string]string{}
 	for _, val := range schema.Values {
@@ -143,73 +284,3 @@ func checkFlag(name, s string) {
 		log.Fatalf("Error: -%v required", name)
 	}
 }
-
-func goCommentify(s string) string {
-	s = strings.Join(strings.Split(s, "\n"), " ")
-	textLength := 75 - len(strings.Replace("", "\t", "    ", 4)+" // ")
-	lines := strings.Split(wordwrap.WrapString(s, uint(textLength)), "\n")
-
-	if len(lines) > 0 {
-		// Remove empty first line.
-		if strings.TrimSpace(lines[0]) == "" {
-			lines = lines[1:]
-		}
-	}
-	if len(lines) > 0 {
-		// Remove empty last line.
-		if strings.TrimSpace(lines[len(lines)-1]) == "" {
-			lines = lines[:len(lines)-1]
-		}
-	}
-
-	for i := range lines {
-		lines[i] = strings.TrimSpace(lines[i])
-	}
-
-	// remove empty lines
-	for i := len(lines) - 1; i >= 0; i-- {
-		if len(lines[i]) == 0 {
-			lines = lines[:i]
-		}
-		break
-	}
-
-	for i := range lines {
-		lines[i] = "// " + lines[i]
-	}
-
-	return strings.Join(lines, "\n")
-}
-
-func goTypeName(name string) string {
-	var b strings.Builder
-	for _, w := range strings.FieldsFunc(name, isSeparator) {
-		b.WriteString(strings.Title(abbreviations(w)))
-	}
-	return b.String()
-}
-
-// abbreviations capitalizes common abbreviations.
-func abbreviations(abv string) string {
-	switch strings.ToLower(abv) {
-	case "id", "ppid", "pid", "mac", "ip", "iana", "uid", "ecs", "url", "os",
-		"http", "dns", "ssl", "tls", "ttl", "uuid":
-		return strings.ToUpper(abv)
-	default:
-		return abv
-	}
-}
-
-// isSeparate returns true if the character is a field name separator. This is
-// used to detect the separators in fields like ephemeral_id or instance.name.
-func isSeparator(c rune) bool {
-	switch c {
-	case '.', '_':
-		return true
-	case '@':
-		// This effectively filters @ from field names.
-		return true
-	default:
-		return false
-	}
-}
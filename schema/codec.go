@@ -0,0 +1,56 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v2"
+)
+
+// codec unmarshals the contents of a file into v, following the usual
+// encoding/json-style "pointer to the value" convention.
+type codec func(contents []byte, v interface{}) error
+
+// codecsByExt maps a file extension (including the leading dot, lower-cased)
+// to the codec used to decode it. New formats can be supported by adding an
+// entry here.
+var codecsByExt = map[string]codec{
+	".yml":  yaml.Unmarshal,
+	".yaml": yaml.Unmarshal,
+	".json": json.Unmarshal,
+	".toml": toml.Unmarshal,
+}
+
+// codecForFile returns the codec registered for file's extension, or an
+// error if the extension is not supported.
+func codecForFile(file string) (codec, error) {
+	ext := strings.ToLower(filepath.Ext(file))
+	c, ok := codecsByExt[ext]
+	if !ok {
+		return nil, fmt.Errorf("unsupported schema file extension '%v' for file '%v'", ext, file)
+	}
+	return c, nil
+}
+
+// UnmarshalFile reads path and unmarshals its contents into v, using the
+// codec registered for its extension (.yml/.yaml/.json/.toml).
+func UnmarshalFile(path string, v interface{}) error {
+	unmarshal, err := codecForFile(path)
+	if err != nil {
+		return err
+	}
+
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read '%v': %+v", path, err)
+	}
+
+	if err := unmarshal(contents, v); err != nil {
+		return fmt.Errorf("error parsing '%v': %+v", path, err)
+	}
+	return nil
+}
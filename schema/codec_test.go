@@ -0,0 +1,47 @@
+package schema
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestUnmarshalFileDispatchesByExtension(t *testing.T) {
+	cases := map[string]string{
+		"fields.yml":  "name: value\n",
+		"fields.yaml": "name: value\n",
+		"fields.json": `{"name": "value"}`,
+		"fields.toml": `name = "value"`,
+	}
+
+	for file, contents := range cases {
+		t.Run(file, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, file)
+			if err := ioutil.WriteFile(path, []byte(contents), 0600); err != nil {
+				t.Fatalf("failed to write fixture: %+v", err)
+			}
+
+			var out map[string]string
+			if err := UnmarshalFile(path, &out); err != nil {
+				t.Fatalf("UnmarshalFile(%v) failed: %+v", file, err)
+			}
+			if out["name"] != "value" {
+				t.Errorf("expected name=value, got %v", out)
+			}
+		})
+	}
+}
+
+func TestUnmarshalFileRejectsUnsupportedExtension(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "fields.ini")
+	if err := ioutil.WriteFile(path, []byte("name=value"), 0600); err != nil {
+		t.Fatalf("failed to write fixture: %+v", err)
+	}
+
+	var out map[string]string
+	if err := UnmarshalFile(path, &out); err == nil {
+		t.Error("expected an error for an unsupported extension, got nil")
+	}
+}
@@ -0,0 +1,200 @@
+package schema
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ChangeKind classifies a single field-level difference found by Diff.
+type ChangeKind string
+
+const (
+	Added              ChangeKind = "added"
+	Removed            ChangeKind = "removed"
+	TypeChanged        ChangeKind = "type-changed"
+	DescriptionChanged ChangeKind = "description-changed"
+)
+
+// Change describes a single difference between two schema versions.
+type Change struct {
+	Kind ChangeKind `json:"kind"`
+	Path string     `json:"path"`
+
+	OldType string `json:"old_type,omitempty"`
+	NewType string `json:"new_type,omitempty"`
+}
+
+func (c Change) String() string {
+	switch c.Kind {
+	case Added:
+		return fmt.Sprintf("+ %v %v", c.Path, c.NewType)
+	case Removed:
+		return fmt.Sprintf("- %v %v", c.Path, c.OldType)
+	case TypeChanged:
+		return fmt.Sprintf("~ %v %v -> %v", c.Path, c.OldType, c.NewType)
+	default:
+		return fmt.Sprintf("~ %v description changed", c.Path)
+	}
+}
+
+// DiffReport is the result of comparing two schema versions with Diff.
+type DiffReport struct {
+	Changes []Change `json:"changes"`
+}
+
+// Breaking reports whether the report contains any Removed or TypeChanged
+// entries, i.e. changes that can break code generated against the old
+// schema.
+func (r DiffReport) Breaking() bool {
+	for _, c := range r.Changes {
+		if c.Kind == Removed || c.Kind == TypeChanged {
+			return true
+		}
+	}
+	return false
+}
+
+// field is a flattened, comparable view of a single schema.Value.
+type field struct {
+	path        string
+	typ         string
+	description string
+}
+
+// Diff compares old and new, classifying every field as Added, Removed,
+// TypeChanged or DescriptionChanged.
+//
+// Both schemas are first normalized into a sorted, canonical text stream of
+// "field.path TYPE" lines, the same approach Go's cmd/api takes to detect
+// API breakage between releases. The two line streams are then compared
+// with a longest-common-subsequence pass: lines present in the LCS are
+// unchanged, a line dropped from old and a line added in new that share a
+// path become a single TypeChanged entry, and everything else left over is
+// a plain Added or Removed entry. This keeps the report stable and
+// review-friendly even when upstream reorders unrelated fields between
+// versions.
+func Diff(old, new *Schema) DiffReport {
+	oldFields := canonicalFields(old)
+	newFields := canonicalFields(new)
+
+	oldLines := make([]string, len(oldFields))
+	for i, f := range oldFields {
+		oldLines[i] = f.path + " " + f.typ
+	}
+	newLines := make([]string, len(newFields))
+	for i, f := range newFields {
+		newLines[i] = f.path + " " + f.typ
+	}
+
+	matchedOld, matchedNew := lcsMatch(oldLines, newLines)
+
+	oldByPath := map[string]field{}
+	for _, f := range oldFields {
+		oldByPath[f.path] = f
+	}
+	newByPath := map[string]field{}
+	for _, f := range newFields {
+		newByPath[f.path] = f
+	}
+
+	unmatchedOld := map[string]field{}
+	for i, f := range oldFields {
+		if !matchedOld[i] {
+			unmatchedOld[f.path] = f
+		}
+	}
+	unmatchedNew := map[string]field{}
+	for i, f := range newFields {
+		if !matchedNew[i] {
+			unmatchedNew[f.path] = f
+		}
+	}
+
+	var changes []Change
+	for path, of := range unmatchedOld {
+		if nf, ok := unmatchedNew[path]; ok {
+			changes = append(changes, Change{Kind: TypeChanged, Path: path, OldType: of.typ, NewType: nf.typ})
+			delete(unmatchedNew, path)
+		} else {
+			changes = append(changes, Change{Kind: Removed, Path: path, OldType: of.typ})
+		}
+	}
+	for path, nf := range unmatchedNew {
+		changes = append(changes, Change{Kind: Added, Path: path, NewType: nf.typ})
+	}
+
+	// Fields whose type didn't change may still have had their description
+	// changed; the line-level LCS above can't see that.
+	for path, of := range oldByPath {
+		nf, ok := newByPath[path]
+		if !ok || of.typ != nf.typ {
+			continue
+		}
+
+		if of.description != nf.description {
+			changes = append(changes, Change{Kind: DescriptionChanged, Path: path})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Path != changes[j].Path {
+			return changes[i].Path < changes[j].Path
+		}
+		return changes[i].Kind < changes[j].Kind
+	})
+	return DiffReport{Changes: changes}
+}
+
+// canonicalFields flattens s.Values into a stable, path-sorted slice.
+func canonicalFields(s *Schema) []field {
+	fields := make([]field, 0, len(s.Values))
+	for path, v := range s.Values {
+		fields = append(fields, field{
+			path:        path,
+			typ:         v.Type.Name,
+			description: v.Description,
+		})
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i].path < fields[j].path })
+	return fields
+}
+
+// lcsMatch computes the longest common subsequence of a and b and returns,
+// for each side, the set of indexes that participate in it.
+func lcsMatch(a, b []string) (matchedA, matchedB map[int]bool) {
+	n, m := len(a), len(b)
+	dp := make([][]int, n+1)
+	for i := range dp {
+		dp[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			switch {
+			case a[i] == b[j]:
+				dp[i][j] = dp[i+1][j+1] + 1
+			case dp[i+1][j] >= dp[i][j+1]:
+				dp[i][j] = dp[i+1][j]
+			default:
+				dp[i][j] = dp[i][j+1]
+			}
+		}
+	}
+
+	matchedA = map[int]bool{}
+	matchedB = map[int]bool{}
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			matchedA[i] = true
+			matchedB[j] = true
+			i++
+			j++
+		case dp[i+1][j] >= dp[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return matchedA, matchedB
+}
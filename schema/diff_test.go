@@ -0,0 +1,92 @@
+package schema
+
+import "testing"
+
+func schemaWithValues(values map[string]TypeInfo) *Schema {
+	s := &Schema{Values: map[string]*Value{}}
+	for path, typ := range values {
+		s.Values[path] = &Value{FlatName: path, Type: typ}
+	}
+	return s
+}
+
+func schemaWithDescriptions(descriptions map[string]string) *Schema {
+	s := &Schema{Values: map[string]*Value{}}
+	for path, desc := range descriptions {
+		s.Values[path] = &Value{FlatName: path, Type: strType, Description: desc}
+	}
+	return s
+}
+
+func TestDiffAddedAndRemoved(t *testing.T) {
+	old := schemaWithValues(map[string]TypeInfo{
+		"source.ip": strType,
+		"event.id":  strType,
+	})
+	new := schemaWithValues(map[string]TypeInfo{
+		"source.ip":   strType,
+		"source.port": longType,
+	})
+
+	report := Diff(old, new)
+
+	var added, removed int
+	for _, c := range report.Changes {
+		switch {
+		case c.Kind == Added && c.Path == "source.port":
+			added++
+		case c.Kind == Removed && c.Path == "event.id":
+			removed++
+		}
+	}
+	if added != 1 {
+		t.Errorf("expected source.port to be reported as added, got changes: %v", report.Changes)
+	}
+	if removed != 1 {
+		t.Errorf("expected event.id to be reported as removed, got changes: %v", report.Changes)
+	}
+	if !report.Breaking() {
+		t.Error("expected report with a removed field to be Breaking")
+	}
+}
+
+func TestDiffTypeChanged(t *testing.T) {
+	old := schemaWithValues(map[string]TypeInfo{"source.port": intType})
+	new := schemaWithValues(map[string]TypeInfo{"source.port": longType})
+
+	report := Diff(old, new)
+
+	if len(report.Changes) != 1 || report.Changes[0].Kind != TypeChanged {
+		t.Fatalf("expected a single TypeChanged entry, got %v", report.Changes)
+	}
+	if !report.Breaking() {
+		t.Error("expected a TypeChanged report to be Breaking")
+	}
+}
+
+func TestDiffDescriptionChanged(t *testing.T) {
+	old := schemaWithDescriptions(map[string]string{"source.ip": "old description"})
+	new := schemaWithDescriptions(map[string]string{"source.ip": "new description"})
+
+	report := Diff(old, new)
+
+	if len(report.Changes) != 1 || report.Changes[0].Kind != DescriptionChanged {
+		t.Fatalf("expected a single DescriptionChanged entry, got %v", report.Changes)
+	}
+	if report.Breaking() {
+		t.Error("a description-only change must not be Breaking")
+	}
+}
+
+func TestDiffNoChanges(t *testing.T) {
+	s := schemaWithValues(map[string]TypeInfo{"source.ip": strType})
+
+	report := Diff(s, s)
+
+	if len(report.Changes) != 0 {
+		t.Fatalf("expected no changes when diffing a schema against itself, got %v", report.Changes)
+	}
+	if report.Breaking() {
+		t.Error("expected an empty report not to be Breaking")
+	}
+}
@@ -0,0 +1,77 @@
+package schema
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// discoverFiles resolves path to the list of schema definition files it
+// refers to. path may be a plain file, a directory (in which case all
+// supported schema files are discovered recursively), or a doublestar glob
+// pattern such as "ecs/**/*.yml".
+func discoverFiles(path string) ([]string, error) {
+	if isGlobPattern(path) {
+		matches, err := doublestar.FilepathGlob(path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid glob pattern '%v': %+v", path, err)
+		}
+		return matches, nil
+	}
+
+	stat, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to access '%v': %+v", path, err)
+	}
+
+	if !stat.IsDir() {
+		return []string{path}, nil
+	}
+
+	var files []string
+	for ext := range codecsByExt {
+		matches, err := doublestar.FilepathGlob(filepath.Join(path, "**/*"+ext))
+		if err != nil {
+			return nil, fmt.Errorf("finding %v files in '%v' failed: %+v", ext, path, err)
+		}
+		files = append(files, matches...)
+	}
+	return files, nil
+}
+
+// isGlobPattern reports whether path contains any glob meta characters and
+// should be expanded via doublestar instead of being treated as a literal
+// file or directory.
+func isGlobPattern(path string) bool {
+	return strings.ContainsAny(path, "*?[{")
+}
+
+// filterExcluded drops any file from files that matches one of the
+// exclude glob patterns (e.g. "**/fixtures/**").
+func filterExcluded(files []string, excludeGlobs []string) ([]string, error) {
+	if len(excludeGlobs) == 0 {
+		return files, nil
+	}
+
+	var kept []string
+	for _, file := range files {
+		excluded := false
+		for _, pattern := range excludeGlobs {
+			ok, err := doublestar.Match(pattern, filepath.ToSlash(file))
+			if err != nil {
+				return nil, fmt.Errorf("invalid exclude glob pattern '%v': %+v", pattern, err)
+			}
+			if ok {
+				excluded = true
+				break
+			}
+		}
+		if !excluded {
+			kept = append(kept, file)
+		}
+	}
+	return kept, nil
+}
@@ -0,0 +1,87 @@
+package schema
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+func writeFixture(t *testing.T, dir, rel string) string {
+	t.Helper()
+	path := filepath.Join(dir, rel)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("failed to create fixture dir: %+v", err)
+	}
+	if err := ioutil.WriteFile(path, []byte("name: value\n"), 0600); err != nil {
+		t.Fatalf("failed to write fixture: %+v", err)
+	}
+	return path
+}
+
+func TestDiscoverFilesRecursesIntoDirectories(t *testing.T) {
+	dir := t.TempDir()
+	writeFixture(t, dir, "base.yml")
+	writeFixture(t, dir, "nested/deep/field.yml")
+	writeFixture(t, dir, "ignored.txt")
+
+	files, err := discoverFiles(dir)
+	if err != nil {
+		t.Fatalf("discoverFiles failed: %+v", err)
+	}
+
+	sort.Strings(files)
+	if len(files) != 2 {
+		t.Fatalf("expected 2 discovered files, got %v", files)
+	}
+}
+
+func TestDiscoverFilesExpandsGlobPattern(t *testing.T) {
+	dir := t.TempDir()
+	writeFixture(t, dir, "a.yml")
+	writeFixture(t, dir, "b.yml")
+	writeFixture(t, dir, "c.json")
+
+	files, err := discoverFiles(filepath.Join(dir, "*.yml"))
+	if err != nil {
+		t.Fatalf("discoverFiles failed: %+v", err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("expected 2 files matching *.yml, got %v", files)
+	}
+}
+
+func TestFilterExcludedDropsMatchingFiles(t *testing.T) {
+	files := []string{
+		"ecs/source.yml",
+		"ecs/fixtures/source.yml",
+		"ecs/destination.yml",
+	}
+
+	kept, err := filterExcluded(files, []string{"**/fixtures/**"})
+	if err != nil {
+		t.Fatalf("filterExcluded failed: %+v", err)
+	}
+
+	if len(kept) != 2 {
+		t.Fatalf("expected 2 files to survive the exclude glob, got %v", kept)
+	}
+	for _, f := range kept {
+		if f == "ecs/fixtures/source.yml" {
+			t.Errorf("expected %v to be excluded, got %v", f, kept)
+		}
+	}
+}
+
+func TestFilterExcludedNoPatternsIsNoop(t *testing.T) {
+	files := []string{"ecs/source.yml"}
+
+	kept, err := filterExcluded(files, nil)
+	if err != nil {
+		t.Fatalf("filterExcluded failed: %+v", err)
+	}
+	if len(kept) != 1 || kept[0] != files[0] {
+		t.Errorf("expected filterExcluded with no patterns to return files unchanged, got %v", kept)
+	}
+}
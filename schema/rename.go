@@ -0,0 +1,61 @@
+package schema
+
+import "strings"
+
+// ApplyNamespaceRenames overrides the identifier for every namespace in s
+// whose FlatName matches a key in renames, e.g. renaming "http.request" to
+// "httpRequest" in generated identifiers. The rename propagates through
+// FlatName on the namespace itself and every descendant namespace and
+// value, since backends (e.g. the default "go" template) generate
+// identifiers from FlatName, not just Namespace.Name. s.Top, s.Namespaces
+// and s.Values are rekeyed to match.
+func ApplyNamespaceRenames(s *Schema, renames map[string]string) {
+	// Resolve every target first: renaming an ancestor rekeys its
+	// descendants, which would hide them from a later lookup by old path.
+	targets := map[*Namespace]string{}
+	for path, newName := range renames {
+		if ns, ok := s.Namespaces[path]; ok {
+			targets[ns] = newName
+		}
+	}
+
+	for ns, newName := range targets {
+		oldName := ns.Name
+		oldFlatName := ns.FlatName
+		ns.Name = newName
+
+		newFlatName := newName
+		if ns.Parent != nil {
+			newFlatName = ns.Parent.FlatName + "." + newName
+		}
+		renameSubtree(s, ns, oldFlatName, newFlatName)
+
+		if ns.Parent == nil {
+			if _, ok := s.Top[oldName]; ok {
+				delete(s.Top, oldName)
+				s.Top[newName] = ns
+			}
+		}
+	}
+}
+
+// renameSubtree updates FlatName for ns and everything nested under it,
+// replacing the oldPrefix portion of every FlatName with newPrefix, and
+// rekeys s.Namespaces/s.Values so they stay reachable by their new path.
+func renameSubtree(s *Schema, ns *Namespace, oldPrefix, newPrefix string) {
+	delete(s.Namespaces, ns.FlatName)
+	ns.FlatName = newPrefix
+	s.Namespaces[newPrefix] = ns
+
+	for _, val := range ns.Values {
+		delete(s.Values, val.FlatName)
+		val.FlatName = newPrefix + strings.TrimPrefix(val.FlatName, oldPrefix)
+		s.Values[val.FlatName] = val
+	}
+
+	for _, child := range ns.Children {
+		childOldPrefix := child.FlatName
+		childNewPrefix := newPrefix + strings.TrimPrefix(childOldPrefix, oldPrefix)
+		renameSubtree(s, child, childOldPrefix, childNewPrefix)
+	}
+}
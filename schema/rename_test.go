@@ -0,0 +1,79 @@
+package schema
+
+import "testing"
+
+// httpRequestFixture builds a minimal schema with a nested
+// http.request.method field, for exercising ApplyNamespaceRenames.
+func httpRequestFixture() *Schema {
+	s := &Schema{
+		Base:       map[string]*Value{},
+		Top:        map[string]*Namespace{},
+		Namespaces: map[string]*Namespace{},
+		Values:     map[string]*Value{},
+	}
+
+	http := &Namespace{Name: "http", FlatName: "http"}
+	request := &Namespace{Name: "request", FlatName: "http.request", Parent: http}
+	method := &Value{Name: "method", FlatName: "http.request.method", Type: strType, Parent: request}
+
+	request.Values = append(request.Values, method)
+	http.Children = append(http.Children, request)
+
+	s.Top["http"] = http
+	s.Namespaces["http"] = http
+	s.Namespaces["http.request"] = request
+	s.Values["http.request.method"] = method
+
+	return s
+}
+
+func TestApplyNamespaceRenamesUpdatesFlatNameThroughSubtree(t *testing.T) {
+	s := httpRequestFixture()
+
+	ApplyNamespaceRenames(s, map[string]string{"http.request": "httpRequest"})
+
+	request, ok := s.Namespaces["http.httpRequest"]
+	if !ok {
+		t.Fatalf("expected renamed namespace to be reachable as 'http.httpRequest', got namespaces: %v", keysOf(s.Namespaces))
+	}
+	if request.Name != "httpRequest" {
+		t.Errorf("expected renamed namespace's Name to be 'httpRequest', got %q", request.Name)
+	}
+	if request.FlatName != "http.httpRequest" {
+		t.Errorf("expected renamed namespace's FlatName to be 'http.httpRequest', got %q", request.FlatName)
+	}
+
+	method, ok := s.Values["http.httpRequest.method"]
+	if !ok {
+		t.Fatalf("expected value to be reachable as 'http.httpRequest.method', got values: %v", keysOf(s.Values))
+	}
+	if method.FlatName != "http.httpRequest.method" {
+		t.Errorf("expected renamed value's FlatName to be 'http.httpRequest.method', got %q", method.FlatName)
+	}
+
+	if _, ok := s.Namespaces["http.request"]; ok {
+		t.Error("expected the old 'http.request' key to be removed from s.Namespaces")
+	}
+	if _, ok := s.Values["http.request.method"]; ok {
+		t.Error("expected the old 'http.request.method' key to be removed from s.Values")
+	}
+}
+
+func keysOf(m interface{}) []string {
+	switch v := m.(type) {
+	case map[string]*Namespace:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		return keys
+	case map[string]*Value:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		return keys
+	default:
+		return nil
+	}
+}
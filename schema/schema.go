@@ -3,11 +3,7 @@ package schema
 import (
 	"fmt"
 	"io/ioutil"
-	"os"
-	"path/filepath"
 	"strings"
-
-	"gopkg.in/yaml.v2"
 )
 
 type Schema struct {
@@ -37,10 +33,29 @@ type Value struct {
 	Description string
 }
 
+// TypeInfo describes how a single ECS field type is represented by each
+// code generation backend. Not every field is relevant to every backend;
+// a backend reads only the fields it understands (e.g. the "go" backend
+// uses Package/Name/Constructor, "jsonschema" uses JSONType/JSONFormat).
 type TypeInfo struct {
-	Package     string
-	Name        string
+	// Package is the Go package import path required by Name, if any.
+	Package string
+	// Name is the Go type name used by the "go" backend, e.g. "time.Time".
+	Name string
+	// Constructor is the accessor suffix used by the "go" backend's
+	// generated constructors, e.g. "Time".
 	Constructor string
+
+	// JSONType is the JSON Schema primitive type used by the "jsonschema"
+	// backend, e.g. "string".
+	JSONType string
+	// JSONFormat is an optional JSON Schema "format" keyword, e.g.
+	// "date-time".
+	JSONFormat string
+
+	// TSType is the TypeScript type name used by the "typescript" backend,
+	// e.g. "Date".
+	TSType string
 }
 
 // Definition represent in yaml file field specifications.
@@ -52,60 +67,75 @@ type Definition struct {
 }
 
 var (
-	boolType  = TypeInfo{Name: "bool", Constructor: "Bool"}
-	strType   = TypeInfo{Name: "string", Constructor: "String"}
-	intType   = TypeInfo{Name: "int", Constructor: "Int"}
-	longType  = TypeInfo{Name: "int64", Constructor: "Int64"}
-	floatType = TypeInfo{Name: "float64", Constructor: "Float64"}
-	dateType  = TypeInfo{Package: "time", Name: "time.Time", Constructor: "Time"}
-	durType   = TypeInfo{Package: "time", Name: "time.Duration", Constructor: "Dur"}
-	objType   = TypeInfo{Name: "map[string]interface{}", Constructor: "Any"}
-	ipType    = TypeInfo{Name: "string", Constructor: "String"}
-	geoType   = TypeInfo{Name: "string", Constructor: "String"}
+	boolType  = TypeInfo{Name: "bool", Constructor: "Bool", JSONType: "boolean", TSType: "boolean"}
+	strType   = TypeInfo{Name: "string", Constructor: "String", JSONType: "string", TSType: "string"}
+	intType   = TypeInfo{Name: "int", Constructor: "Int", JSONType: "integer", TSType: "number"}
+	longType  = TypeInfo{Name: "int64", Constructor: "Int64", JSONType: "integer", TSType: "number"}
+	floatType = TypeInfo{Name: "float64", Constructor: "Float64", JSONType: "number", TSType: "number"}
+	dateType  = TypeInfo{Package: "time", Name: "time.Time", Constructor: "Time", JSONType: "string", JSONFormat: "date-time", TSType: "Date"}
+	durType   = TypeInfo{Package: "time", Name: "time.Duration", Constructor: "Dur", JSONType: "string", TSType: "string"}
+	objType   = TypeInfo{Name: "map[string]interface{}", Constructor: "Any", JSONType: "object", TSType: "Record<string, unknown>"}
+	ipType    = TypeInfo{Name: "string", Constructor: "String", JSONType: "string", JSONFormat: "ipv4", TSType: "string"}
+	geoType   = TypeInfo{Name: "string", Constructor: "String", JSONType: "string", TSType: "string"}
 )
 
-func LoadFromFiles(version string, paths []string, exclude map[string]bool) (*Schema, error) {
-	defs, err := loadDefs(paths)
+// LoadFromFiles loads schema definitions from paths, which may be plain
+// files, directories (searched recursively for supported schema files), or
+// doublestar glob patterns such as "ecs/**/*.yml". Any file matching one of
+// excludeGlobs is dropped before parsing. types resolves the Go
+// representation of each field's ECS type; if nil, DefaultTypeRegistry is
+// used.
+func LoadFromFiles(version string, paths []string, excludeGlobs []string, types TypeRegistry, exclude map[string]bool) (*Schema, error) {
+	if types == nil {
+		types = DefaultTypeRegistry()
+	}
+
+	defs, err := loadDefs(paths, excludeGlobs)
+	if err != nil {
+		return nil, err
+	}
+
+	flat, err := flattenDefs("", defs, types)
 	if err != nil {
 		return nil, err
 	}
 
-	schema := buildSchema(version, flattenDefs("", defs), exclude)
+	schema := buildSchema(version, flat, exclude)
 	copyDescriptions(schema, "", defs)
 	return schema, nil
 }
 
-func loadDefs(paths []string) (map[string]Definition, error) {
+func loadDefs(paths []string, excludeGlobs []string) (map[string]Definition, error) {
 	var files []string
 
 	for _, path := range paths {
-		stat, err := os.Stat(path)
+		matches, err := discoverFiles(path)
 		if err != nil {
-			return nil, fmt.Errorf("failed to access '%v': %+v", path, err)
-		}
-
-		if !stat.IsDir() {
-			files = append(files, path)
-			continue
+			return nil, err
 		}
+		files = append(files, matches...)
+	}
 
-		local, err := filepath.Glob(filepath.Join(path, "*.yml"))
-		if err != nil {
-			return nil, fmt.Errorf("finding yml files in '%v' failed: %+v", path, err)
-		}
-		files = append(files, local...)
+	files, err := filterExcluded(files, excludeGlobs)
+	if err != nil {
+		return nil, err
 	}
 
 	// load definitions
 	defs := map[string]Definition{}
 	for _, file := range files {
+		unmarshal, err := codecForFile(file)
+		if err != nil {
+			return nil, err
+		}
+
 		contents, err := ioutil.ReadFile(file)
 		if err != nil {
 			return nil, fmt.Errorf("error reading file %v: %+v", file, err)
 		}
 
 		var fileDefs map[string]Definition
-		if err := yaml.Unmarshal(contents, &fileDefs); err != nil {
+		if err := unmarshal(contents, &fileDefs); err != nil {
 			return nil, fmt.Errorf("error parsing file %v: %+v", file, err)
 		}
 
@@ -117,7 +147,7 @@ func loadDefs(paths []string) (map[string]Definition, error) {
 	return defs, nil
 }
 
-func flattenDefs(path string, in map[string]Definition) map[string]TypeInfo {
+func flattenDefs(path string, in map[string]Definition, types TypeRegistry) (map[string]TypeInfo, error) {
 	filtered := map[string]TypeInfo{}
 	for fldPath, fld := range in {
 		if path != "" {
@@ -125,14 +155,22 @@ func flattenDefs(path string, in map[string]Definition) map[string]TypeInfo {
 		}
 
 		if fld.Type != "group" {
-			filtered[fldPath] = getType(fld.Type, fldPath)
+			ti, err := types.resolve(fld.Type, fldPath)
+			if err != nil {
+				return nil, err
+			}
+			filtered[fldPath] = ti
 		}
 
-		for k, v := range flattenDefs(fldPath, fld.Fields) {
+		nested, err := flattenDefs(fldPath, fld.Fields, types)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range nested {
 			filtered[k] = v
 		}
 	}
-	return filtered
+	return filtered, nil
 }
 
 func buildSchema(version string, defs map[string]TypeInfo, exclude map[string]bool) *Schema {
@@ -270,30 +308,3 @@ func normalizePath(in string) string {
 	}
 	return in
 }
-
-func getType(typ, name string) TypeInfo {
-	switch typ {
-	case "keyword", "text":
-		return strType
-	case "bool", "boolean":
-		return boolType
-	case "integer":
-		return intType
-	case "long":
-		return longType
-	case "float":
-		return floatType
-	case "date":
-		return dateType
-	case "duration":
-		return durType
-	case "object":
-		return objType
-	case "ip":
-		return ipType
-	case "geo_point":
-		return geoType
-	default:
-		panic(fmt.Sprintf("unknown type '%v' in field '%v'", typ, name))
-	}
-}
@@ -0,0 +1,104 @@
+package schema
+
+import "fmt"
+
+// TypeRegistry maps ECS field type names (as used in schema definition
+// files, e.g. "keyword" or "scaled_float") to the Go type used to represent
+// them in generated code.
+type TypeRegistry map[string]TypeInfo
+
+// DefaultTypeRegistry returns a TypeRegistry pre-populated with the field
+// types go-ecsfieldgen has always understood natively.
+func DefaultTypeRegistry() TypeRegistry {
+	return TypeRegistry{
+		"keyword":   strType,
+		"text":      strType,
+		"bool":      boolType,
+		"boolean":   boolType,
+		"integer":   intType,
+		"long":      longType,
+		"float":     floatType,
+		"date":      dateType,
+		"duration":  durType,
+		"object":    objType,
+		"ip":        ipType,
+		"geo_point": geoType,
+	}
+}
+
+// Register adds typ to the registry, or overrides its TypeInfo if already
+// present.
+func (r TypeRegistry) Register(typ string, info TypeInfo) {
+	r[typ] = info
+}
+
+// Lookup returns the TypeInfo registered for typ, if any.
+func (r TypeRegistry) Lookup(typ string) (TypeInfo, bool) {
+	info, ok := r[typ]
+	return info, ok
+}
+
+// resolve looks up typ, returning a descriptive error naming fieldName when
+// typ has not been registered.
+func (r TypeRegistry) resolve(typ, fieldName string) (TypeInfo, error) {
+	info, ok := r[typ]
+	if !ok {
+		return TypeInfo{}, fmt.Errorf("unknown type '%v' in field '%v': register it via TypeRegistry.Register or the -types file", typ, fieldName)
+	}
+	return info, nil
+}
+
+// TypeOverride is the on-disk representation of a single TypeRegistry
+// entry, as loaded from a -types or -config file.
+type TypeOverride struct {
+	Package     string `yaml:"package" json:"package" toml:"package"`
+	GoType      string `yaml:"go_type" json:"go_type" toml:"go_type"`
+	Constructor string `yaml:"constructor" json:"constructor" toml:"constructor"`
+	JSONType    string `yaml:"json_type" json:"json_type" toml:"json_type"`
+	JSONFormat  string `yaml:"json_format" json:"json_format" toml:"json_format"`
+	TSType      string `yaml:"ts_type" json:"ts_type" toml:"ts_type"`
+}
+
+// ToTypeInfo converts o to the TypeInfo it describes.
+func (o TypeOverride) ToTypeInfo() TypeInfo {
+	return TypeInfo{
+		Package:     o.Package,
+		Name:        o.GoType,
+		Constructor: o.Constructor,
+		JSONType:    o.JSONType,
+		JSONFormat:  o.JSONFormat,
+		TSType:      o.TSType,
+	}
+}
+
+// NewTypeRegistry builds a TypeRegistry from a map of raw overrides, as
+// decoded from a -types or -config file.
+func NewTypeRegistry(overrides map[string]TypeOverride) TypeRegistry {
+	types := TypeRegistry{}
+	for typ, o := range overrides {
+		types.Register(typ, o.ToTypeInfo())
+	}
+	return types
+}
+
+// LoadTypeRegistryFile loads additional type declarations from a
+// YAML/JSON/TOML file and returns them as a TypeRegistry. The file maps ECS
+// type names to their representation in each backend, e.g.:
+//
+//	wildcard:
+//	  go_type: string
+//	  constructor: String
+//	  json_type: string
+//	  ts_type: string
+//	scaled_float:
+//	  go_type: float64
+//	  constructor: Float64
+//	  json_type: number
+//	  ts_type: number
+func LoadTypeRegistryFile(path string) (TypeRegistry, error) {
+	var raw map[string]TypeOverride
+	if err := UnmarshalFile(path, &raw); err != nil {
+		return nil, fmt.Errorf("failed to load types file: %+v", err)
+	}
+	return NewTypeRegistry(raw), nil
+}
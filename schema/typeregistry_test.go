@@ -0,0 +1,59 @@
+package schema
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestTypeRegistryRegisterOverridesDefault(t *testing.T) {
+	reg := DefaultTypeRegistry()
+
+	custom := TypeInfo{Name: "string", Constructor: "String"}
+	reg.Register("keyword", custom)
+
+	got, ok := reg.Lookup("keyword")
+	if !ok || got != custom {
+		t.Errorf("expected Register to override 'keyword', got %v, ok=%v", got, ok)
+	}
+}
+
+func TestTypeRegistryResolveErrorsOnUnknownType(t *testing.T) {
+	reg := DefaultTypeRegistry()
+
+	if _, err := reg.resolve("wildcard", "source.ip"); err == nil {
+		t.Error("expected resolve to error on an unregistered type, got nil")
+	}
+
+	if _, err := reg.resolve("keyword", "source.ip"); err != nil {
+		t.Errorf("expected resolve to succeed for a known type, got %+v", err)
+	}
+}
+
+func TestLoadTypeRegistryFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "types.yml")
+	contents := `
+wildcard:
+  go_type: string
+  constructor: String
+  json_type: string
+  ts_type: string
+`
+	if err := ioutil.WriteFile(path, []byte(contents), 0600); err != nil {
+		t.Fatalf("failed to write fixture: %+v", err)
+	}
+
+	reg, err := LoadTypeRegistryFile(path)
+	if err != nil {
+		t.Fatalf("LoadTypeRegistryFile failed: %+v", err)
+	}
+
+	info, ok := reg.Lookup("wildcard")
+	if !ok {
+		t.Fatalf("expected 'wildcard' to be registered, got %v", reg)
+	}
+	if info.Name != "string" || info.Constructor != "String" || info.JSONType != "string" || info.TSType != "string" {
+		t.Errorf("unexpected TypeInfo for 'wildcard': %+v", info)
+	}
+}